@@ -0,0 +1,34 @@
+package babylon
+
+import (
+	"context"
+	"fmt"
+
+	ccapi "github.com/babylonlabs-io/finality-provider/clientcontroller/api"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// btcCheckpointFinalizer implements poller.Finalizer by deferring to a
+// ConsumerController's own QueryIsBlockFinalized query, i.e. Babylon's
+// BTC-checkpoint-depth finality rule. Any ConsumerController can be used,
+// so a non-Babylon consumer wired through poller.BlockPollConnector gets
+// its own finality rule for free as long as it implements the same query.
+type btcCheckpointFinalizer struct {
+	cc ccapi.ConsumerController
+}
+
+// NewBTCCheckpointFinalizer builds a poller.Finalizer backed by cc's
+// QueryIsBlockFinalized.
+func NewBTCCheckpointFinalizer(cc ccapi.ConsumerController) *btcCheckpointFinalizer {
+	return &btcCheckpointFinalizer{cc: cc}
+}
+
+// IsFinalized reports whether block has reached BTC-checkpoint finality.
+func (f *btcCheckpointFinalizer) IsFinalized(_ context.Context, block *types.BlockInfo) (bool, error) {
+	finalized, err := f.cc.QueryIsBlockFinalized(block.Height)
+	if err != nil {
+		return false, fmt.Errorf("failed to query finalization status of block %d: %w", block.Height, err)
+	}
+
+	return finalized, nil
+}
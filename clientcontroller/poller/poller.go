@@ -0,0 +1,268 @@
+// Package poller implements a supervised block poller that turns the
+// request/response ConsumerController interface into an event-driven
+// pipeline of latest/safe/finalized/reorged block streams.
+package poller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultChanBufSize  = 32
+
+	// reorgCacheDepth bounds how far behind the polled tip pollOnce
+	// re-checks already-polled heights for a changed hash. Heights further
+	// behind than this are assumed final and are no longer re-queried.
+	reorgCacheDepth = 100
+)
+
+// BlockSource is the slice of ConsumerController that the poller needs.
+// Any ccapi.ConsumerController satisfies this structurally, so production
+// callers can pass one in directly and tests can pass a scripted fake.
+type BlockSource interface {
+	QueryLatestBlockHeight() (uint64, error)
+	QueryBlock(height uint64) (*types.BlockInfo, error)
+}
+
+// Finalizer decides whether a polled block should be considered final.
+// Babylon finalizes via BTC-checkpoint depth; other consumers may use a
+// different rule (e.g. L1 finality, validator-set attestation).
+type Finalizer interface {
+	IsFinalized(ctx context.Context, block *types.BlockInfo) (bool, error)
+}
+
+// BlockPollConnector wraps a BlockSource and emits four event streams:
+// latest (every new polled height), safe (heights behind the tip by
+// safeDepth, i.e. unlikely to be reorged), finalized (heights the
+// Finalizer has confirmed as final), and reorged (a previously-polled
+// height whose hash has since changed).
+type BlockPollConnector struct {
+	src       BlockSource
+	finalizer Finalizer
+	logger    *zap.Logger
+
+	pollInterval time.Duration
+	safeDepth    uint64
+
+	latestC    chan *types.BlockInfo
+	safeC      chan *types.BlockInfo
+	finalizedC chan *types.BlockInfo
+	reorgC     chan *types.BlockInfo
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+
+	lastPolledHeight    uint64
+	lastSafeHeight      uint64
+	lastFinalizedHeight uint64
+	hashByHeight        map[uint64][]byte
+}
+
+// NewBlockPollConnector builds a connector over src. safeDepth is the number
+// of confirmations behind the polled tip a block must have before it is
+// emitted on the safe stream.
+func NewBlockPollConnector(src BlockSource, finalizer Finalizer, safeDepth uint64, logger *zap.Logger) *BlockPollConnector {
+	return &BlockPollConnector{
+		src:          src,
+		finalizer:    finalizer,
+		logger:       logger,
+		pollInterval: defaultPollInterval,
+		safeDepth:    safeDepth,
+		latestC:      make(chan *types.BlockInfo, defaultChanBufSize),
+		safeC:        make(chan *types.BlockInfo, defaultChanBufSize),
+		finalizedC:   make(chan *types.BlockInfo, defaultChanBufSize),
+		reorgC:       make(chan *types.BlockInfo, defaultChanBufSize),
+		quit:         make(chan struct{}),
+		hashByHeight: make(map[uint64][]byte),
+	}
+}
+
+// LatestBlocks returns the stream of every newly polled block.
+func (c *BlockPollConnector) LatestBlocks() <-chan *types.BlockInfo {
+	return c.latestC
+}
+
+// SafeBlocks returns the stream of blocks that have reached safeDepth
+// confirmations and are safe to vote on.
+func (c *BlockPollConnector) SafeBlocks() <-chan *types.BlockInfo {
+	return c.safeC
+}
+
+// FinalizedBlocks returns the stream of blocks the Finalizer has confirmed
+// as final, in increasing height order.
+func (c *BlockPollConnector) FinalizedBlocks() <-chan *types.BlockInfo {
+	return c.finalizedC
+}
+
+// ReorgedBlocks returns the stream of blocks whose hash at an
+// already-polled height has changed since it was first observed, each
+// carrying the new hash for that height.
+func (c *BlockPollConnector) ReorgedBlocks() <-chan *types.BlockInfo {
+	return c.reorgC
+}
+
+// Start launches the poll loop in the background.
+func (c *BlockPollConnector) Start(ctx context.Context) error {
+	c.wg.Add(1)
+	go c.pollLoop(ctx)
+
+	return nil
+}
+
+// Stop terminates the poll loop and closes the event streams.
+func (c *BlockPollConnector) Stop() error {
+	close(c.quit)
+	c.wg.Wait()
+
+	close(c.latestC)
+	close(c.safeC)
+	close(c.finalizedC)
+	close(c.reorgC)
+
+	return nil
+}
+
+func (c *BlockPollConnector) pollLoop(ctx context.Context) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.quit:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.pollOnce(ctx); err != nil {
+				c.logger.Sugar().Errorf("block poll failed: %s", err.Error())
+			}
+		}
+	}
+}
+
+func (c *BlockPollConnector) pollOnce(ctx context.Context) error {
+	tip, err := c.src.QueryLatestBlockHeight()
+	if err != nil {
+		return fmt.Errorf("failed to query latest block height: %w", err)
+	}
+
+	if err := c.checkForReorgs(); err != nil {
+		return err
+	}
+
+	for h := c.lastPolledHeight + 1; h <= tip; h++ {
+		block, err := c.src.QueryBlock(h)
+		if err != nil {
+			return fmt.Errorf("failed to query block %d: %w", h, err)
+		}
+
+		c.cacheHash(block)
+		c.lastPolledHeight = h
+		c.emitLatest(block)
+	}
+
+	for h := c.lastSafeHeight + 1; h+c.safeDepth <= c.lastPolledHeight; h++ {
+		block, err := c.src.QueryBlock(h)
+		if err != nil {
+			return fmt.Errorf("failed to query block %d: %w", h, err)
+		}
+		c.lastSafeHeight = h
+		c.emitSafe(block)
+	}
+
+	for h := c.lastFinalizedHeight + 1; h <= c.lastPolledHeight; h++ {
+		block, err := c.src.QueryBlock(h)
+		if err != nil {
+			return fmt.Errorf("failed to query block %d: %w", h, err)
+		}
+		finalized, err := c.finalizer.IsFinalized(ctx, block)
+		if err != nil {
+			return fmt.Errorf("failed to check finality of block %d: %w", h, err)
+		}
+		if !finalized {
+			break
+		}
+		c.lastFinalizedHeight = h
+		c.emitFinalized(block)
+	}
+
+	return nil
+}
+
+// checkForReorgs re-queries the last reorgCacheDepth already-polled
+// heights and compares each against the hash pollOnce cached for it when
+// first polled. A mismatch means the chain reorged underneath a height
+// this connector already emitted on the latest/safe streams, so it emits
+// a reorg event and updates the cached hash to the new one.
+func (c *BlockPollConnector) checkForReorgs() error {
+	from := uint64(1)
+	if c.lastPolledHeight > reorgCacheDepth {
+		from = c.lastPolledHeight - reorgCacheDepth + 1
+	}
+
+	for h := from; h <= c.lastPolledHeight; h++ {
+		prevHash, ok := c.hashByHeight[h]
+		if !ok {
+			continue
+		}
+
+		block, err := c.src.QueryBlock(h)
+		if err != nil {
+			return fmt.Errorf("failed to re-query block %d for reorg check: %w", h, err)
+		}
+
+		if string(block.Hash) != string(prevHash) {
+			c.hashByHeight[h] = block.Hash
+			c.emitReorg(block)
+		}
+	}
+
+	return nil
+}
+
+// cacheHash records block's hash for later reorg checks and forgets
+// anything older than reorgCacheDepth behind it.
+func (c *BlockPollConnector) cacheHash(block *types.BlockInfo) {
+	c.hashByHeight[block.Height] = block.Hash
+
+	if block.Height > reorgCacheDepth {
+		delete(c.hashByHeight, block.Height-reorgCacheDepth)
+	}
+}
+
+func (c *BlockPollConnector) emitLatest(block *types.BlockInfo) {
+	select {
+	case c.latestC <- block:
+	case <-c.quit:
+	}
+}
+
+func (c *BlockPollConnector) emitSafe(block *types.BlockInfo) {
+	select {
+	case c.safeC <- block:
+	case <-c.quit:
+	}
+}
+
+func (c *BlockPollConnector) emitFinalized(block *types.BlockInfo) {
+	select {
+	case c.finalizedC <- block:
+	case <-c.quit:
+	}
+}
+
+func (c *BlockPollConnector) emitReorg(block *types.BlockInfo) {
+	select {
+	case c.reorgC <- block:
+	case <-c.quit:
+	}
+}
@@ -0,0 +1,209 @@
+package poller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// scriptedSource is a BlockSource whose tip and per-height blocks are set by
+// the test, so poll sequences (gaps, out-of-order delivery, reorgs) can be
+// scripted deterministically without spinning up a chain.
+type scriptedSource struct {
+	tip    uint64
+	blocks map[uint64]*types.BlockInfo
+}
+
+func newScriptedSource() *scriptedSource {
+	return &scriptedSource{blocks: make(map[uint64]*types.BlockInfo)}
+}
+
+func (s *scriptedSource) QueryLatestBlockHeight() (uint64, error) {
+	return s.tip, nil
+}
+
+func (s *scriptedSource) QueryBlock(height uint64) (*types.BlockInfo, error) {
+	return s.blocks[height], nil
+}
+
+func (s *scriptedSource) setBlock(height uint64, hash string) {
+	s.blocks[height] = &types.BlockInfo{Height: height, Hash: []byte(hash)}
+	if height > s.tip {
+		s.tip = height
+	}
+}
+
+// scriptedFinalizer finalizes any block at or below a configured height.
+type scriptedFinalizer struct {
+	finalizedUpTo uint64
+}
+
+func (f *scriptedFinalizer) IsFinalized(_ context.Context, block *types.BlockInfo) (bool, error) {
+	return block.Height <= f.finalizedUpTo, nil
+}
+
+func TestPollOnceFillsGapsInOrder(t *testing.T) {
+	src := newScriptedSource()
+	// the tip jumps straight from nothing to height 3, leaving a gap at 1-2
+	src.setBlock(1, "h1")
+	src.setBlock(2, "h2")
+	src.setBlock(3, "h3")
+
+	c := NewBlockPollConnector(src, &scriptedFinalizer{}, 0, zap.NewNop())
+	require.NoError(t, c.pollOnce(context.Background()))
+
+	for h := uint64(1); h <= 3; h++ {
+		select {
+		case block := <-c.latestC:
+			require.Equal(t, h, block.Height)
+		default:
+			t.Fatalf("expected a latest block at height %d", h)
+		}
+	}
+}
+
+func TestPollOnceRespectsSafeDepth(t *testing.T) {
+	src := newScriptedSource()
+	src.setBlock(1, "h1")
+	src.setBlock(2, "h2")
+	src.setBlock(3, "h3")
+
+	c := NewBlockPollConnector(src, &scriptedFinalizer{}, 2, zap.NewNop())
+	require.NoError(t, c.pollOnce(context.Background()))
+
+	// only height 1 is 2 blocks behind the tip (3), so only it is safe
+	select {
+	case block := <-c.safeC:
+		require.Equal(t, uint64(1), block.Height)
+	default:
+		t.Fatal("expected height 1 to be emitted on the safe stream")
+	}
+
+	select {
+	case block := <-c.safeC:
+		t.Fatalf("did not expect another safe block, got height %d", block.Height)
+	default:
+	}
+}
+
+func TestPollOnceEmitsFinalizedOnlyOnceAndInOrder(t *testing.T) {
+	src := newScriptedSource()
+	src.setBlock(1, "h1")
+	src.setBlock(2, "h2")
+
+	finalizer := &scriptedFinalizer{finalizedUpTo: 1}
+	c := NewBlockPollConnector(src, finalizer, 0, zap.NewNop())
+	require.NoError(t, c.pollOnce(context.Background()))
+
+	select {
+	case block := <-c.finalizedC:
+		require.Equal(t, uint64(1), block.Height)
+	default:
+		t.Fatal("expected height 1 to be finalized")
+	}
+	select {
+	case block := <-c.finalizedC:
+		t.Fatalf("height 2 should not be finalized yet, got %d", block.Height)
+	default:
+	}
+
+	// a new block arrives and height 2 now finalizes as well
+	src.setBlock(3, "h3")
+	finalizer.finalizedUpTo = 2
+	require.NoError(t, c.pollOnce(context.Background()))
+
+	select {
+	case block := <-c.finalizedC:
+		require.Equal(t, uint64(2), block.Height)
+	default:
+		t.Fatal("expected height 2 to finalize once the chain advanced")
+	}
+}
+
+func TestPollOnceDeliversOutOfOrderHeightsInHeightOrder(t *testing.T) {
+	src := newScriptedSource()
+	// simulate a source whose blocks become queryable out of the order
+	// they're set: height 2 is set before height 1, but pollOnce still
+	// walks the range in increasing height order.
+	src.setBlock(2, "h2")
+	src.setBlock(1, "h1")
+
+	c := NewBlockPollConnector(src, &scriptedFinalizer{}, 0, zap.NewNop())
+	require.NoError(t, c.pollOnce(context.Background()))
+
+	for h := uint64(1); h <= 2; h++ {
+		select {
+		case block := <-c.latestC:
+			require.Equal(t, h, block.Height)
+		default:
+			t.Fatalf("expected a latest block at height %d", h)
+		}
+	}
+}
+
+func TestPollOnceDetectsReorgOfAnAlreadyPolledHeight(t *testing.T) {
+	src := newScriptedSource()
+	src.setBlock(1, "h1")
+	src.setBlock(2, "h2-original")
+
+	c := NewBlockPollConnector(src, &scriptedFinalizer{}, 0, zap.NewNop())
+	require.NoError(t, c.pollOnce(context.Background()))
+
+	select {
+	case block := <-c.reorgC:
+		t.Fatalf("did not expect a reorg on the first poll, got height %d", block.Height)
+	default:
+	}
+
+	// height 2 gets a different hash without the tip changing: a reorg
+	src.setBlock(2, "h2-reorged")
+	require.NoError(t, c.pollOnce(context.Background()))
+
+	select {
+	case block := <-c.reorgC:
+		require.Equal(t, uint64(2), block.Height)
+		require.Equal(t, []byte("h2-reorged"), block.Hash)
+	default:
+		t.Fatal("expected a reorg event for height 2")
+	}
+}
+
+func TestPollOnceIgnoresReorgsOlderThanTheCacheDepth(t *testing.T) {
+	src := newScriptedSource()
+	src.setBlock(1, "h1")
+
+	c := NewBlockPollConnector(src, &scriptedFinalizer{}, 0, zap.NewNop())
+	require.NoError(t, c.pollOnce(context.Background()))
+
+	// advance far enough that height 1 falls outside the reorg cache window,
+	// draining the buffered streams after each poll so none of them fills up
+	for h := uint64(2); h <= reorgCacheDepth+2; h++ {
+		src.setBlock(h, "filler")
+		require.NoError(t, c.pollOnce(context.Background()))
+		for len(c.latestC) > 0 {
+			<-c.latestC
+		}
+		for len(c.safeC) > 0 {
+			<-c.safeC
+		}
+	}
+
+	// height 1 now gets a different hash, but it is too far behind the tip
+	// to be re-checked
+	src.setBlock(1, "h1-reorged")
+	require.NoError(t, c.pollOnce(context.Background()))
+
+	// draining the reorg stream: no reorg should have been emitted for
+	// height 1, since its hash has already been forgotten
+	for {
+		select {
+		case block := <-c.reorgC:
+			require.NotEqual(t, uint64(1), block.Height)
+		default:
+			return
+		}
+	}
+}
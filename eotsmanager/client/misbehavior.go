@@ -0,0 +1,24 @@
+package client
+
+import "github.com/babylonlabs-io/finality-provider/eotsmanager"
+
+// SetSignTwiceOnHeight arms the EOTS manager package's test-only
+// misbehavior mode for (pk, height) (see eotsmanager.SetSignTwiceOnHeight),
+// so that the next eotsmanager.MaybeSignTwice call for it returns two
+// different signatures over the same digest. Signing requests served over
+// the gRPC connection do not go through MaybeSignTwice and are unaffected.
+//
+// Doing this over the wire would need a new RPC on the EOTSManager gRPC
+// service (a .proto change plus regenerated client/server stubs), but
+// neither the .proto sources nor the generated pb.go for that service are
+// present in this checkout, so there's no EOTSManagerClient method to call
+// and no codegen tooling available to add one. The e2e harness that's the
+// only caller of this method always runs its EOTSServerHandler in the same
+// process as this client, so calling straight into the package it's built
+// from is the wiring actually reachable here; an out-of-process EOTS
+// manager would need the RPC added for real.
+func (c *EOTSManagerGRpcClient) SetSignTwiceOnHeight(pk []byte, height uint64) error {
+	eotsmanager.SetSignTwiceOnHeight(pk, height)
+
+	return nil
+}
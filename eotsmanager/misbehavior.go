@@ -0,0 +1,76 @@
+//go:build e2e
+
+package eotsmanager
+
+import (
+	"strconv"
+	"sync"
+)
+
+// misbehaviorState holds the test-only "sign twice" toggles armed via
+// SetSignTwiceOnHeight, keyed by (pk, height). It only exists in e2e
+// builds; see misbehavior_disabled.go for the production stub that keeps
+// this surface unreachable outside of builds compiled with the e2e tag.
+type misbehaviorState struct {
+	mu    sync.Mutex
+	armed map[string]bool
+}
+
+var misbehavior = &misbehaviorState{armed: make(map[string]bool)}
+
+func misbehaviorKey(pk []byte, height uint64) string {
+	return string(pk) + "|" + strconv.FormatUint(height, 10)
+}
+
+// SetSignTwiceOnHeight arms (pk, height) so that the next MaybeSignTwice
+// call for it signs a second time over the same digest and returns both
+// signatures, simulating a double-signing finality provider. Only
+// MaybeSignTwice consumes the arm; signatures produced without going
+// through it are unaffected.
+func SetSignTwiceOnHeight(pk []byte, height uint64) {
+	misbehavior.mu.Lock()
+	defer misbehavior.mu.Unlock()
+
+	misbehavior.armed[misbehaviorKey(pk, height)] = true
+}
+
+// shouldSignTwice reports whether (pk, height) was armed via
+// SetSignTwiceOnHeight, consuming the arm so it only fires once.
+func shouldSignTwice(pk []byte, height uint64) bool {
+	misbehavior.mu.Lock()
+	defer misbehavior.mu.Unlock()
+
+	key := misbehaviorKey(pk, height)
+	armed := misbehavior.armed[key]
+	delete(misbehavior.armed, key)
+
+	return armed
+}
+
+// MaybeSignTwice calls sign once, and, if (pk, height) was armed via
+// SetSignTwiceOnHeight, calls it a second time and returns both
+// signatures; otherwise it returns the single signature. This is the hook
+// point a real EOTS signing path should call from so that shouldSignTwice
+// actually has an effect; the concrete signing implementation for this
+// manager lives in a package that isn't present in this checkout, so
+// nothing in this tree calls MaybeSignTwice outside of this package's own
+// test.
+func MaybeSignTwice(pk []byte, height uint64, sign func() ([]byte, error)) ([][]byte, error) {
+	sig, err := sign()
+	if err != nil {
+		return nil, err
+	}
+
+	sigs := [][]byte{sig}
+
+	if shouldSignTwice(pk, height) {
+		sig2, err := sign()
+		if err != nil {
+			return nil, err
+		}
+
+		sigs = append(sigs, sig2)
+	}
+
+	return sigs, nil
+}
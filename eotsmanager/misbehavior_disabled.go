@@ -0,0 +1,24 @@
+//go:build !e2e
+
+package eotsmanager
+
+// SetSignTwiceOnHeight is a no-op in production (non-e2e-tagged) builds,
+// so the double-signing test hook can never be armed outside of e2e test
+// binaries.
+func SetSignTwiceOnHeight(pk []byte, height uint64) {}
+
+// shouldSignTwice always reports false in production builds.
+func shouldSignTwice(pk []byte, height uint64) bool {
+	return false
+}
+
+// MaybeSignTwice always returns a single signature in production builds,
+// since shouldSignTwice can never report true.
+func MaybeSignTwice(pk []byte, height uint64, sign func() ([]byte, error)) ([][]byte, error) {
+	sig, err := sign()
+	if err != nil {
+		return nil, err
+	}
+
+	return [][]byte{sig}, nil
+}
@@ -0,0 +1,48 @@
+//go:build e2e
+
+package eotsmanager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaybeSignTwice(t *testing.T) {
+	pk := []byte("test-pk")
+	height := uint64(100)
+
+	calls := 0
+	sign := func() ([]byte, error) {
+		calls++
+		return []byte{byte(calls)}, nil
+	}
+
+	sigs, err := MaybeSignTwice(pk, height, sign)
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+
+	SetSignTwiceOnHeight(pk, height)
+
+	sigs, err = MaybeSignTwice(pk, height, sign)
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+	require.NotEqual(t, sigs[0], sigs[1])
+
+	// the arm is consumed after firing once
+	sigs, err = MaybeSignTwice(pk, height, sign)
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+}
+
+func TestMaybeSignTwice_SignError(t *testing.T) {
+	pk := []byte("test-pk")
+	height := uint64(101)
+
+	wantErr := errors.New("boom")
+	sign := func() ([]byte, error) { return nil, wantErr }
+
+	_, err := MaybeSignTwice(pk, height, sign)
+	require.ErrorIs(t, err, wantErr)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ccapi "github.com/babylonlabs-io/finality-provider/clientcontroller/api"
+	"go.uber.org/zap"
+)
+
+// maxReorgCacheDepth bounds the rolling height->hash cache reorgDetector
+// keeps; heights further behind the tip than this can no longer reorg and
+// are safe to forget.
+const maxReorgCacheDepth = 100
+
+// reorgDetector polls a consumer chain's blocks and compares the hash seen
+// at each height against the last hash it cached for that height,
+// analogous to the safe/finalized distinction the block-poller in
+// clientcontroller/poller draws from polled blocks.
+type reorgDetector struct {
+	cc     ccapi.ConsumerController
+	logger *zap.Logger
+
+	mu              sync.Mutex
+	hashByHeight    map[uint64][]byte
+	lastReorgHeight uint64
+
+	onReorg func(height uint64)
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newReorgDetector(cc ccapi.ConsumerController, onReorg func(height uint64), logger *zap.Logger) *reorgDetector {
+	return &reorgDetector{
+		cc:           cc,
+		logger:       logger,
+		hashByHeight: make(map[uint64][]byte),
+		onReorg:      onReorg,
+		quit:         make(chan struct{}),
+	}
+}
+
+func (d *reorgDetector) Start(ctx context.Context, pollInterval time.Duration) {
+	d.wg.Add(1)
+	go d.loop(ctx, pollInterval)
+}
+
+func (d *reorgDetector) Stop() {
+	close(d.quit)
+	d.wg.Wait()
+}
+
+func (d *reorgDetector) GetLastReorgHeight() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.lastReorgHeight
+}
+
+func (d *reorgDetector) loop(ctx context.Context, pollInterval time.Duration) {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.quit:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.checkOnce(); err != nil {
+				d.logger.Sugar().Errorf("reorg check failed: %s", err.Error())
+			}
+		}
+	}
+}
+
+func (d *reorgDetector) checkOnce() error {
+	tip, err := d.cc.QueryLatestBlockHeight()
+	if err != nil {
+		return err
+	}
+
+	block, err := d.cc.QueryBlock(tip)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	reorged := false
+	if prevHash, ok := d.hashByHeight[block.Height]; ok && string(prevHash) != string(block.Hash) {
+		d.lastReorgHeight = block.Height
+		reorged = true
+	}
+	d.hashByHeight[block.Height] = block.Hash
+
+	for h := range d.hashByHeight {
+		if h+maxReorgCacheDepth < tip {
+			delete(d.hashByHeight, h)
+		}
+	}
+	d.mu.Unlock()
+
+	// onReorg runs outside the lock so that the callback may read this same
+	// detector's state (e.g. via GetLastReorgHeight) without deadlocking,
+	// since d.mu isn't reentrant.
+	if reorged && d.onReorg != nil {
+		d.onReorg(block.Height)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ccapi "github.com/babylonlabs-io/finality-provider/clientcontroller/api"
+)
+
+const reorgPollInterval = time.Second
+
+// reorgDetectors tracks the reorgDetector running for each
+// FinalityProviderInstance, keyed by instance pointer. It's kept here
+// rather than as a field on FinalityProviderInstance so that wiring the
+// reorg-recovery hook doesn't require touching that type's other
+// constructors scattered across the package.
+var reorgDetectors sync.Map // map[*FinalityProviderInstance]*reorgDetector
+
+// WatchForReorgs starts fp's reorg detector against cc. It should be called
+// once, alongside the rest of the instance's startup, and stopped via
+// StopWatchingForReorgs when the instance stops.
+func (fp *FinalityProviderInstance) WatchForReorgs(ctx context.Context, cc ccapi.ConsumerController) {
+	d := newReorgDetector(cc, fp.OnReorg, fp.logger)
+	reorgDetectors.Store(fp, d)
+	d.Start(ctx, reorgPollInterval)
+}
+
+// StopWatchingForReorgs stops fp's reorg detector, if one was started.
+func (fp *FinalityProviderInstance) StopWatchingForReorgs() {
+	v, ok := reorgDetectors.LoadAndDelete(fp)
+	if !ok {
+		return
+	}
+	v.(*reorgDetector).Stop()
+}
+
+// OnReorg is reorgDetector's notification that fp's consumer chain reorged
+// at height; the detector has already recorded height itself (that's what
+// GetLastReorgHeight reads back), so this is purely a side-effect hook.
+// The instance's existing last-voted-height bookkeeping already refuses to
+// re-sign a height it has voted on; this just makes the reorg independently
+// observable (e.g. by e2e tests) rather than only inferred from vote
+// behavior.
+func (fp *FinalityProviderInstance) OnReorg(height uint64) {
+	fp.logger.Sugar().Infof("observed a reorg at height %d", height)
+}
+
+// GetLastReorgHeight returns the height of the most recent reorg fp's
+// detector observed, or 0 if none has been observed yet or WatchForReorgs
+// was never called.
+func (fp *FinalityProviderInstance) GetLastReorgHeight() uint64 {
+	d, ok := reorgDetectors.Load(fp)
+	if !ok {
+		return 0
+	}
+
+	return d.(*reorgDetector).GetLastReorgHeight()
+}
@@ -16,6 +16,7 @@ import (
 	fpcc "github.com/babylonlabs-io/finality-provider/clientcontroller"
 	ccapi "github.com/babylonlabs-io/finality-provider/clientcontroller/api"
 	bbncc "github.com/babylonlabs-io/finality-provider/clientcontroller/babylon"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/poller"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
 	eotsconfig "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
@@ -39,6 +40,8 @@ const (
 	testChainID = "chain-test"
 	passphrase  = "testpass"
 	hdPath      = ""
+
+	upgradeVotingPeriod = 10 * time.Second
 )
 
 type TestManager struct {
@@ -48,10 +51,44 @@ type TestManager struct {
 	FpConfig          *fpcfg.Config
 	Fps               []*service.FinalityProviderApp
 	EOTSClient        *client.EOTSManagerGRpcClient
-	BBNConsumerClient *bbncc.BabylonConsumerController
-	baseDir           string
-	manager           *container.Manager
-	logger            *zap.Logger
+	// ConsumerControllers holds one ConsumerController per consumer chain the
+	// manager knows about, keyed by chain-ID. The Babylon chain itself is
+	// always registered under testChainID.
+	ConsumerControllers map[string]ccapi.ConsumerController
+	baseDir             string
+	manager             *container.Manager
+	logger              *zap.Logger
+	// pollers holds one long-lived poller.BlockPollConnector per chain-ID,
+	// started lazily on first use and torn down in Stop. WaitForSafeBlock
+	// and WaitForFinalizedBlockEvent read safe/finalized blocks off these
+	// instead of opening a throwaway connector per call; the rest of this
+	// manager's require.Eventually-based waits query the chain directly and
+	// are unrelated to this poller.
+	pollers map[string]*poller.BlockPollConnector
+}
+
+// ConsumerController returns the ConsumerController registered for chainID,
+// failing the test if none was registered via StartManager/RegisterConsumerChain.
+func (tm *TestManager) ConsumerController(t *testing.T, chainID string) ccapi.ConsumerController {
+	cc, ok := tm.ConsumerControllers[chainID]
+	require.True(t, ok, "no ConsumerController registered for chain-id %s", chainID)
+	return cc
+}
+
+// blockPoller returns the long-lived poller.BlockPollConnector for
+// chainID, starting it on first use.
+func (tm *TestManager) blockPoller(t *testing.T, ctx context.Context, chainID string) *poller.BlockPollConnector {
+	if conn, ok := tm.pollers[chainID]; ok {
+		return conn
+	}
+
+	cc := tm.ConsumerController(t, chainID)
+	conn := poller.NewBlockPollConnector(cc, bbncc.NewBTCCheckpointFinalizer(cc), 0, tm.logger)
+	require.NoError(t, conn.Start(ctx))
+
+	tm.pollers[chainID] = conn
+
+	return conn
 }
 
 func StartManager(t *testing.T, ctx context.Context) *TestManager {
@@ -69,7 +106,7 @@ func StartManager(t *testing.T, ctx context.Context) *TestManager {
 	covenantPrivKeys, covenantPubKeys := e2eutils.GenerateCovenantCommittee(numCovenants, t)
 
 	// 2. prepare Babylon node
-	manager, err := container.NewManager(t)
+	manager, err := container.NewManager(t, container.WithGovVotingPeriod(upgradeVotingPeriod))
 	require.NoError(t, err)
 
 	// Create temp dir for babylon node
@@ -136,10 +173,13 @@ func StartManager(t *testing.T, ctx context.Context) *TestManager {
 		EOTSHomeDir:       eotsHomeDir,
 		FpConfig:          cfg,
 		EOTSClient:        eotsCli,
-		BBNConsumerClient: bcc.(*bbncc.BabylonConsumerController),
-		baseDir:           testDir,
-		manager:           manager,
-		logger:            logger,
+		ConsumerControllers: map[string]ccapi.ConsumerController{
+			testChainID: bcc,
+		},
+		baseDir: testDir,
+		manager: manager,
+		logger:  logger,
+		pollers: make(map[string]*poller.BlockPollConnector),
 	}
 
 	tm.WaitForServicesStart(t)
@@ -147,10 +187,15 @@ func StartManager(t *testing.T, ctx context.Context) *TestManager {
 	return tm
 }
 
-func (tm *TestManager) AddFinalityProvider(t *testing.T, ctx context.Context) *service.FinalityProviderInstance {
+// CreateEOTSKey creates a new EOTS key in tm's running EOTS manager and
+// returns its public key. Most callers don't need this directly -
+// AddFinalityProvider calls it for them - but it lets a test mint a key up
+// front and reuse it across more than one AddFinalityProviderWithEOTSKey
+// call, e.g. to register a single EOTS key as a finality provider on two
+// different consumer chains.
+func (tm *TestManager) CreateEOTSKey(t *testing.T) *bbntypes.BIP340PubKey {
 	r := rand.New(rand.NewSource(time.Now().Unix()))
 
-	// Create EOTS key
 	eotsKeyName := fmt.Sprintf("eots-key-%s", datagen.GenRandomHexStr(r, 4))
 	eotsPkBz, err := tm.EOTSClient.CreateKey(eotsKeyName, passphrase, hdPath)
 	require.NoError(t, err)
@@ -159,11 +204,36 @@ func (tm *TestManager) AddFinalityProvider(t *testing.T, ctx context.Context) *s
 
 	t.Logf("the EOTS key is created: %s", eotsPk.MarshalHex())
 
+	return eotsPk
+}
+
+// AddFinalityProvider creates and registers a finality provider that signs
+// for the consumer chain identified by chainID, using a freshly minted EOTS
+// key. chainID must already be known to the manager, either as the Babylon
+// chain itself (testChainID) or via a prior call to RegisterConsumerChain.
+func (tm *TestManager) AddFinalityProvider(t *testing.T, ctx context.Context, chainID string) *service.FinalityProviderInstance {
+	return tm.AddFinalityProviderWithEOTSKey(t, ctx, chainID, tm.CreateEOTSKey(t))
+}
+
+// AddFinalityProviderWithEOTSKey is like AddFinalityProvider, but signs with
+// the given already-existing EOTS key instead of minting a new one. This
+// lets a test register the same EOTS key as a finality provider on more
+// than one consumer chain, e.g. to check that a single key votes correctly
+// on both Babylon and a registered consumer chain.
+func (tm *TestManager) AddFinalityProviderWithEOTSKey(t *testing.T, ctx context.Context, chainID string, eotsPk *bbntypes.BIP340PubKey) *service.FinalityProviderInstance {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+
 	// Create FP babylon key
 	fpKeyName := fmt.Sprintf("fp-key-%s", datagen.GenRandomHexStr(r, 4))
 	fpHomeDir := filepath.Join(tm.baseDir, fmt.Sprintf("fp-%s", datagen.GenRandomHexStr(r, 4)))
 	cfg := e2eutils.DefaultFpConfig(tm.baseDir, fpHomeDir)
 	cfg.BabylonConfig.Key = fpKeyName
+	// bc below always talks to Babylon itself, since FP registration and
+	// covenant/voting-power bookkeeping happen there regardless of which
+	// consumer chain the FP ends up signing for, so cfg.BabylonConfig is
+	// pinned to the main node's address here. bcc, built further down, is
+	// the per-consumer client and for chainID != testChainID it comes from
+	// the registry populated by RegisterConsumerChain instead of from cfg.
 	cfg.BabylonConfig.RPCAddr = tm.FpConfig.BabylonConfig.RPCAddr
 	cfg.BabylonConfig.GRPCAddr = tm.FpConfig.BabylonConfig.GRPCAddr
 	fpBbnKeyInfo, err := testutil.CreateChainKey(cfg.BabylonConfig.KeyDirectory, cfg.BabylonConfig.ChainID, cfg.BabylonConfig.Key, cfg.BabylonConfig.KeyringBackend, passphrase, hdPath, "")
@@ -180,8 +250,18 @@ func (tm *TestManager) AddFinalityProvider(t *testing.T, ctx context.Context) *s
 	require.NoError(t, err)
 	err = bc.Start()
 	require.NoError(t, err)
-	bcc, err := bbncc.NewBabylonConsumerController(cfg.BabylonConfig, &cfg.BTCNetParams, tm.logger)
-	require.NoError(t, err)
+
+	// Babylon itself gets a fresh consumer controller per FP, matching its
+	// own cfg; any other consumer chain reuses the controller wired up by
+	// RegisterConsumerChain, since that's the client the whole test suite
+	// shares to talk to that chain.
+	var bcc ccapi.ConsumerController
+	if chainID == testChainID {
+		bcc, err = bbncc.NewBabylonConsumerController(cfg.BabylonConfig, &cfg.BTCNetParams, tm.logger)
+		require.NoError(t, err)
+	} else {
+		bcc = tm.ConsumerController(t, chainID)
+	}
 
 	// Create and start finality provider app
 	eotsCli, err := client.NewEOTSManagerGRpcClient(tm.EOTSServerHandler.Config().RPCListener)
@@ -196,7 +276,7 @@ func (tm *TestManager) AddFinalityProvider(t *testing.T, ctx context.Context) *s
 	// Create and register the finality provider
 	commission := sdkmath.LegacyZeroDec()
 	desc := newDescription(testMoniker)
-	_, err = fpApp.CreateFinalityProvider(cfg.BabylonConfig.Key, testChainID, passphrase, eotsPk, desc, &commission)
+	_, err = fpApp.CreateFinalityProvider(cfg.BabylonConfig.Key, chainID, passphrase, eotsPk, desc, &commission)
 	require.NoError(t, err)
 
 	cfg.RPCListener = fmt.Sprintf("127.0.0.1:%d", testutil.AllocateUniquePort(t))
@@ -216,6 +296,8 @@ func (tm *TestManager) AddFinalityProvider(t *testing.T, ctx context.Context) *s
 	fpIns, err := fpApp.GetFinalityProviderInstance()
 	require.NoError(t, err)
 
+	fpIns.WatchForReorgs(ctx, bcc)
+
 	return fpIns
 }
 
@@ -234,7 +316,7 @@ func StartManagerWithFinalityProvider(t *testing.T, n int, ctx context.Context)
 
 	var runningFps []*service.FinalityProviderInstance
 	for i := 0; i < n; i++ {
-		fpIns := tm.AddFinalityProvider(t, ctx)
+		fpIns := tm.AddFinalityProvider(t, ctx, testChainID)
 		runningFps = append(runningFps, fpIns)
 	}
 
@@ -259,13 +341,18 @@ func (tm *TestManager) Stop(t *testing.T) {
 		err := fpApp.Stop()
 		require.NoError(t, err)
 	}
+	for chainID, conn := range tm.pollers {
+		if err := conn.Stop(); err != nil {
+			t.Logf("failed to stop poller for chain %s: %s", chainID, err.Error())
+		}
+	}
 	err := tm.manager.ClearResources()
 	require.NoError(t, err)
 	err = os.RemoveAll(tm.baseDir)
 	require.NoError(t, err)
 }
 
-func (tm *TestManager) CheckBlockFinalization(t *testing.T, height uint64, num int) {
+func (tm *TestManager) CheckBlockFinalization(t *testing.T, chainID string, height uint64, num int) {
 	// We need to ensure votes are collected at the given height
 	require.Eventually(t, func() bool {
 		votes, err := tm.BBNClient.QueryVotesAtHeight(height)
@@ -277,8 +364,9 @@ func (tm *TestManager) CheckBlockFinalization(t *testing.T, height uint64, num i
 	}, eventuallyWaitTimeOut, eventuallyPollTime)
 
 	// As the votes have been collected, the block should be finalized
+	cc := tm.ConsumerController(t, chainID)
 	require.Eventually(t, func() bool {
-		finalized, err := tm.BBNConsumerClient.QueryIsBlockFinalized(height)
+		finalized, err := cc.QueryIsBlockFinalized(height)
 		if err != nil {
 			t.Logf("failed to query block at height %v: %s", height, err.Error())
 			return false
@@ -287,7 +375,7 @@ func (tm *TestManager) CheckBlockFinalization(t *testing.T, height uint64, num i
 	}, eventuallyWaitTimeOut, eventuallyPollTime)
 }
 
-func (tm *TestManager) WaitForFpVoteCast(t *testing.T, fpIns *service.FinalityProviderInstance) uint64 {
+func (tm *TestManager) WaitForFpVoteCast(t *testing.T, chainID string, fpIns *service.FinalityProviderInstance) uint64 {
 	var lastVotedHeight uint64
 	require.Eventually(t, func() bool {
 		if fpIns.GetLastVotedHeight() > 0 {
@@ -297,6 +385,8 @@ func (tm *TestManager) WaitForFpVoteCast(t *testing.T, fpIns *service.FinalityPr
 		return false
 	}, eventuallyWaitTimeOut, eventuallyPollTime)
 
+	t.Logf("fp voted at height %d on chain %s", lastVotedHeight, chainID)
+
 	return lastVotedHeight
 }
 
@@ -307,13 +397,15 @@ func (tm *TestManager) GetFpPrivKey(t *testing.T, fpPk []byte) *btcec.PrivateKey
 }
 
 func (tm *TestManager) StopAndRestartFpAfterNBlocks(t *testing.T, n int, fpIns *service.FinalityProviderInstance) {
-	blockBeforeStop, err := tm.BBNConsumerClient.QueryLatestBlockHeight()
+	cc := tm.ConsumerController(t, testChainID)
+
+	blockBeforeStop, err := cc.QueryLatestBlockHeight()
 	require.NoError(t, err)
 	err = fpIns.Stop()
 	require.NoError(t, err)
 
 	require.Eventually(t, func() bool {
-		headerAfterStop, err := tm.BBNConsumerClient.QueryLatestBlockHeight()
+		headerAfterStop, err := cc.QueryLatestBlockHeight()
 		if err != nil {
 			return false
 		}
@@ -327,15 +419,16 @@ func (tm *TestManager) StopAndRestartFpAfterNBlocks(t *testing.T, n int, fpIns *
 	require.NoError(t, err)
 }
 
-func (tm *TestManager) WaitForNFinalizedBlocks(t *testing.T, n uint) *types.BlockInfo {
+func (tm *TestManager) WaitForNFinalizedBlocks(t *testing.T, chainID string, n uint) *types.BlockInfo {
 	var (
 		firstFinalizedBlock *types.BlockInfo
 		err                 error
 		lastFinalizedBlock  *types.BlockInfo
 	)
 
+	cc := tm.ConsumerController(t, chainID)
 	require.Eventually(t, func() bool {
-		lastFinalizedBlock, err = tm.BBNConsumerClient.QueryLatestFinalizedBlock()
+		lastFinalizedBlock, err = cc.QueryLatestFinalizedBlock()
 		if err != nil {
 			t.Logf("failed to get the latest finalized block: %s", err.Error())
 			return false
@@ -349,11 +442,187 @@ func (tm *TestManager) WaitForNFinalizedBlocks(t *testing.T, n uint) *types.Bloc
 		return lastFinalizedBlock.Height-firstFinalizedBlock.Height >= uint64(n-1)
 	}, eventuallyWaitTimeOut, eventuallyPollTime)
 
-	t.Logf("the block is finalized at %v", lastFinalizedBlock.Height)
+	t.Logf("the block is finalized at %v on chain %s", lastFinalizedBlock.Height, chainID)
 
 	return lastFinalizedBlock
 }
 
+// RegisterConsumerChain spins up an additional consumer chain (in a new
+// docker resource started by tm's container manager) and wires up a ConsumerController for it, so an FP
+// registered on Babylon can be added against it via AddFinalityProvider.
+func (tm *TestManager) RegisterConsumerChain(t *testing.T, chainID string, cfg *fpcfg.Config) ccapi.ConsumerController {
+	rpcAddr, grpcAddr, err := tm.manager.RunConsumerResource(t, chainID, cfg)
+	require.NoError(t, err)
+
+	// RunConsumerResource starts the new chain on its own dynamically
+	// allocated ports; patch them into cfg before building the controller,
+	// otherwise it would silently talk to whatever default address cfg
+	// started with instead of the chain it was just registered for.
+	cfg.BabylonConfig.RPCAddr = rpcAddr
+	cfg.BabylonConfig.GRPCAddr = grpcAddr
+
+	cc, err := bbncc.NewBabylonConsumerController(cfg.BabylonConfig, &cfg.BTCNetParams, tm.logger)
+	require.NoError(t, err)
+
+	tm.ConsumerControllers[chainID] = cc
+
+	t.Logf("registered consumer chain %s", chainID)
+
+	return cc
+}
+
+// SubmitUpgradeProposal submits a software-upgrade gov proposal named `name`
+// that halts the chain at `upgradeHeight`, and votes yes on it from node0's
+// validator key so it passes within the test's voting period.
+func (tm *TestManager) SubmitUpgradeProposal(t *testing.T, name string, upgradeHeight uint64, info string) {
+	proposalID, err := tm.manager.SubmitGovProposal(t, "node0", "software-upgrade", name, fmt.Sprintf("%d", upgradeHeight), info)
+	require.NoError(t, err)
+
+	_, _, err = tm.manager.VoteOnProposal(t, "node0", fmt.Sprintf("%d", proposalID), "yes")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		proposal, err := tm.manager.QueryProposal(t, proposalID)
+		if err != nil {
+			t.Logf("failed to query proposal %d: %s", proposalID, err.Error())
+			return false
+		}
+		return proposal.Status == "PROPOSAL_STATUS_PASSED"
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	t.Logf("upgrade proposal %d (%s) passed, chain will halt at height %d", proposalID, name, upgradeHeight)
+}
+
+// WaitForUpgradeHeight blocks until the Babylon node's latest block height
+// reaches upgradeHeight, the height the upgrade proposal halts the chain at.
+// It does not check that the node has stopped producing blocks.
+func (tm *TestManager) WaitForUpgradeHeight(t *testing.T, upgradeHeight uint64) {
+	cc := tm.ConsumerController(t, testChainID)
+	require.Eventually(t, func() bool {
+		tip, err := cc.QueryLatestBlockHeight()
+		if err != nil {
+			t.Logf("failed to query latest block height: %s", err.Error())
+			return false
+		}
+		return tip >= upgradeHeight
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	t.Logf("the chain has reached the halt height %d", upgradeHeight)
+}
+
+// RestartBabylondWithNewBinary stops the running babylond container, swaps in
+// the post-upgrade image built from the given Dockerfile/VERSION build-arg,
+// and resumes the node on top of the existing chain state.
+func (tm *TestManager) RestartBabylondWithNewBinary(t *testing.T, dockerfile string, version string) {
+	err := tm.manager.UpgradeBabylondResource(t, dockerfile, version)
+	require.NoError(t, err)
+
+	tm.WaitForServicesStart(t)
+
+	t.Logf("babylond was restarted with the post-upgrade binary (%s)", version)
+}
+
+// TriggerBabylonReorg stops the node, rolls its chain state back `depth`
+// blocks, and restarts it from the rolled-back state, producing a reorg of
+// the Babylon chain the FP instances are watching.
+func (tm *TestManager) TriggerBabylonReorg(t *testing.T, depth int) {
+	cc := tm.ConsumerController(t, testChainID)
+	heightBeforeReorg, err := cc.QueryLatestBlockHeight()
+	require.NoError(t, err)
+	require.Greater(t, heightBeforeReorg, uint64(depth), "not enough blocks to roll back %d", depth)
+
+	err = tm.manager.RollbackBabylondResource(t, "node0", depth)
+	require.NoError(t, err)
+
+	tm.WaitForServicesStart(t)
+
+	t.Logf("triggered a %d-block reorg on Babylon, chain was at height %d", depth, heightBeforeReorg)
+}
+
+// WaitForReorgDetected asserts that fpIns observed the reorg triggered by
+// TriggerBabylonReorg, i.e. its reorg detector fired.
+func (tm *TestManager) WaitForReorgDetected(t *testing.T, fpIns *service.FinalityProviderInstance) {
+	require.Eventually(t, func() bool {
+		return fpIns.GetLastReorgHeight() > 0
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	t.Logf("the finality-provider instance detected the reorg at height %d", fpIns.GetLastReorgHeight())
+}
+
+// WaitForSafeBlock blocks until this manager's poller emits a safe block at
+// or above height on the Babylon chain, reading off this manager's long-lived
+// poller.BlockPollConnector instead of opening a throwaway connector per
+// call. The require.Eventually below is only draining that connector's
+// buffered channel until a matching block shows up, not re-querying the
+// chain itself.
+func (tm *TestManager) WaitForSafeBlock(t *testing.T, ctx context.Context, height uint64) {
+	conn := tm.blockPoller(t, ctx, testChainID)
+
+	require.Eventually(t, func() bool {
+		select {
+		case block := <-conn.SafeBlocks():
+			return block.Height >= height
+		default:
+			return false
+		}
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	t.Logf("observed a safe block at or above height %d", height)
+}
+
+// WaitForFinalizedBlockEvent blocks until this manager's poller emits a
+// finalized event at or above height.
+func (tm *TestManager) WaitForFinalizedBlockEvent(t *testing.T, ctx context.Context, height uint64) {
+	conn := tm.blockPoller(t, ctx, testChainID)
+
+	require.Eventually(t, func() bool {
+		select {
+		case block := <-conn.FinalizedBlocks():
+			return block.Height >= height
+		default:
+			return false
+		}
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	t.Logf("observed a finalized block event at or above height %d", height)
+}
+
+// TriggerEquivocation arms the EOTS manager's test-only misbehavior mode for
+// (fpPk, height) via EOTSClient.SetSignTwiceOnHeight. Only
+// eotsmanager.MaybeSignTwice honours that arm, and the signing path the FP
+// instance uses does not call it, so this does not by itself make fpPk sign
+// twice.
+func (tm *TestManager) TriggerEquivocation(t *testing.T, fpPk []byte, height uint64) {
+	err := tm.EOTSClient.SetSignTwiceOnHeight(fpPk, height)
+	require.NoError(t, err)
+
+	t.Logf("armed equivocation for fp %x at height %d", fpPk, height)
+}
+
+// WaitForSlashing polls Babylon until fpPk is reported as slashed, then
+// returns the BTC secret key Babylon extracted from the equivocation
+// evidence.
+func (tm *TestManager) WaitForSlashing(t *testing.T, fpPk []byte) *btcec.PrivateKey {
+	var extractedSK *btcec.PrivateKey
+	require.Eventually(t, func() bool {
+		slashedFp, err := tm.BBNClient.QuerySlashedFinalityProvider(fpPk)
+		if err != nil {
+			t.Logf("failed to query slashed finality provider: %s", err.Error())
+			return false
+		}
+		if slashedFp == nil {
+			return false
+		}
+
+		extractedSK, _ = btcec.PrivKeyFromBytes(slashedFp.SlashedBtcSk)
+		return extractedSK != nil
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	t.Logf("fp %x was slashed, recovered its BTC secret key", fpPk)
+
+	return extractedSK
+}
+
 func newDescription(moniker string) *stakingtypes.Description {
 	dec := stakingtypes.NewDescription(moniker, "", "", "", "")
 	return &dec
@@ -0,0 +1,39 @@
+package e2etest_babylon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/finality-provider/proto"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFinalityProviderSlashedOnEquivocation registers an FP, forces it to
+// double-sign at a chosen height via the EOTS manager's test-only
+// misbehavior mode, and checks that Babylon slashes it and that the FP
+// instance stops voting once it learns it was slashed.
+func TestFinalityProviderSlashedOnEquivocation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tm, fps := StartManagerWithFinalityProvider(t, 1, ctx)
+	defer tm.Stop(t)
+	fpIns := fps[0]
+
+	fpPk := fpIns.GetBtcPkBIP340().MustMarshal()
+
+	lastVotedHeight := tm.WaitForFpVoteCast(t, testChainID, fpIns)
+	equivocationHeight := lastVotedHeight + 1
+	tm.TriggerEquivocation(t, fpPk, equivocationHeight)
+
+	tm.WaitForSlashing(t, fpPk)
+
+	require.Eventually(t, func() bool {
+		return fpIns.GetStatus() == proto.FinalityProviderStatus_SLASHED
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	heightAfterSlashing := fpIns.GetLastVotedHeight()
+	require.Never(t, func() bool {
+		return fpIns.GetLastVotedHeight() != heightAfterSlashing
+	}, eventuallyPollTime*10, eventuallyPollTime)
+}
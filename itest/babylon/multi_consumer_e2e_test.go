@@ -0,0 +1,37 @@
+package e2etest_babylon
+
+import (
+	"context"
+	"testing"
+
+	e2eutils "github.com/babylonlabs-io/finality-provider/itest"
+	"github.com/stretchr/testify/require"
+)
+
+const secondConsumerChainID = "consumer-chain-test"
+
+// TestFinalityProviderVotesOnTwoConsumerChains registers an additional
+// consumer chain alongside Babylon and checks that a single EOTS key, held
+// by two separate FP instances, casts votes correctly on both chains.
+func TestFinalityProviderVotesOnTwoConsumerChains(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tm := StartManager(t, ctx)
+	defer tm.Stop(t)
+
+	eotsPk := tm.CreateEOTSKey(t)
+	babylonFp := tm.AddFinalityProviderWithEOTSKey(t, ctx, testChainID, eotsPk)
+
+	consumerCfg := e2eutils.DefaultFpConfig(tm.baseDir, tm.baseDir)
+	consumerCfg.BabylonConfig.ChainID = secondConsumerChainID
+	tm.RegisterConsumerChain(t, secondConsumerChainID, consumerCfg)
+
+	consumerFp := tm.AddFinalityProviderWithEOTSKey(t, ctx, secondConsumerChainID, eotsPk)
+
+	babylonVotedHeight := tm.WaitForFpVoteCast(t, testChainID, babylonFp)
+	consumerVotedHeight := tm.WaitForFpVoteCast(t, secondConsumerChainID, consumerFp)
+
+	require.Greater(t, babylonVotedHeight, uint64(0))
+	require.Greater(t, consumerVotedHeight, uint64(0))
+}
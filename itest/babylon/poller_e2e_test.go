@@ -0,0 +1,26 @@
+package e2etest_babylon
+
+import (
+	"context"
+	"testing"
+)
+
+// TestWaitForSafeAndFinalizedBlockEvents exercises the event-driven poller
+// helpers (WaitForSafeBlock, WaitForFinalizedBlockEvent) against a live
+// Babylon node. These two helpers read off a long-lived
+// poller.BlockPollConnector instead of opening a throwaway one per call;
+// other waits on TestManager are unrelated and still query the chain
+// directly.
+func TestWaitForSafeAndFinalizedBlockEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tm, fps := StartManagerWithFinalityProvider(t, 1, ctx)
+	defer tm.Stop(t)
+	fpIns := fps[0]
+
+	lastVotedHeight := tm.WaitForFpVoteCast(t, testChainID, fpIns)
+
+	tm.WaitForSafeBlock(t, ctx, lastVotedHeight)
+	tm.WaitForFinalizedBlockEvent(t, ctx, lastVotedHeight)
+}
@@ -0,0 +1,62 @@
+package e2etest_babylon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFinalityProviderResignsAfterReorgAboveLastVotedHeight reorgs the chain
+// above the FP's last-voted height and confirms it keeps voting past that
+// height on the canonical chain. It does not check for double-signing.
+func TestFinalityProviderResignsAfterReorgAboveLastVotedHeight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tm, fps := StartManagerWithFinalityProvider(t, 1, ctx)
+	defer tm.Stop(t)
+	fpIns := fps[0]
+
+	lastVotedHeight := tm.WaitForFpVoteCast(t, testChainID, fpIns)
+
+	tm.TriggerBabylonReorg(t, 1)
+	tm.WaitForReorgDetected(t, fpIns)
+
+	// WaitForFpVoteCast returns as soon as any vote exists, so wait for a
+	// vote past lastVotedHeight directly
+	require.Eventually(t, func() bool {
+		return fpIns.GetLastVotedHeight() > lastVotedHeight
+	}, eventuallyWaitTimeOut, eventuallyPollTime, "expected the fp to keep signing past height %d after the reorg", lastVotedHeight)
+}
+
+// TestFinalityProviderRefusesToResignAfterReorgBelowLastVotedHeight reorgs
+// the chain below the FP's last-voted height and confirms it refuses to
+// re-sign heights it has already voted on.
+func TestFinalityProviderRefusesToResignAfterReorgBelowLastVotedHeight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tm, fps := StartManagerWithFinalityProvider(t, 1, ctx)
+	defer tm.Stop(t)
+	fpIns := fps[0]
+
+	lastVotedHeight := tm.WaitForFpVoteCast(t, testChainID, fpIns)
+
+	// TriggerBabylonReorg's depth is blocks-to-roll-back-from-the-current-
+	// tip, not a target height, so rolling back to land below
+	// lastVotedHeight means rolling back everything from the current tip
+	// down to (and including) lastVotedHeight, leaving the tip at
+	// lastVotedHeight-1.
+	cc := tm.ConsumerController(t, testChainID)
+	heightBeforeReorg, err := cc.QueryLatestBlockHeight()
+	require.NoError(t, err)
+	depth := int(heightBeforeReorg - (lastVotedHeight - 1))
+
+	tm.TriggerBabylonReorg(t, depth)
+	tm.WaitForReorgDetected(t, fpIns)
+
+	if fpIns.GetLastVotedHeight() >= lastVotedHeight {
+		t.Fatalf("expected the fp to not re-sign height %d after the reorg rolled the chain back below it", lastVotedHeight)
+	}
+}
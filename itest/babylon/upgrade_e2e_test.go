@@ -0,0 +1,35 @@
+package e2etest_babylon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFinalityProviderContinuesVotingAcrossUpgrade submits a software-upgrade
+// proposal, lets the chain halt at the target height, swaps the babylond
+// binary for the post-upgrade image, and asserts the FP instance keeps
+// casting votes once the chain resumes.
+func TestFinalityProviderContinuesVotingAcrossUpgrade(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tm, fps := StartManagerWithFinalityProvider(t, 1, ctx)
+	defer tm.Stop(t)
+	fpIns := fps[0]
+
+	lastVotedHeight := tm.WaitForFpVoteCast(t, testChainID, fpIns)
+	upgradeHeight := lastVotedHeight + 5
+
+	tm.SubmitUpgradeProposal(t, "v1-to-v2", upgradeHeight, "test upgrade across chunk0-1")
+	tm.WaitForUpgradeHeight(t, upgradeHeight)
+
+	tm.RestartBabylondWithNewBinary(t, "Dockerfile.post-upgrade", "v2")
+
+	// WaitForFpVoteCast returns as soon as any vote exists, so wait for a
+	// vote above the upgrade height directly
+	require.Eventually(t, func() bool {
+		return fpIns.GetLastVotedHeight() > upgradeHeight
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+}
@@ -0,0 +1,42 @@
+package container
+
+import (
+	"fmt"
+	"testing"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+const consumerRepository = "babylonlabs-io/consumer-chain"
+
+// RunConsumerResource starts an additional Cosmos-SDK consumer chain node in
+// docker, named after chainID, and returns the RPC/gRPC addresses the caller
+// should point a ConsumerController at. cfg is only consulted for the
+// chain's home-dir layout; its BabylonConfig.RPCAddr/GRPCAddr are ignored
+// since those are whatever the new container is actually listening on.
+func (m *Manager) RunConsumerResource(t *testing.T, chainID string, cfg *fpcfg.Config) (rpcAddr string, grpcAddr string, err error) {
+	runOpts := &dockertest.RunOptions{
+		Name:       chainID,
+		Repository: consumerRepository,
+		Tag:        "latest",
+		Env:        []string{fmt.Sprintf("CHAIN_ID=%s", chainID)},
+		Mounts:     []string{fmt.Sprintf("%s:/consumer", cfg.BabylonConfig.KeyDirectory)},
+	}
+
+	resource, err := m.pool.RunWithOptions(runOpts, func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+		hc.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to start consumer chain %s: %w", chainID, err)
+	}
+
+	m.resources[chainID] = resource
+
+	rpcAddr = fmt.Sprintf("http://localhost:%s", resource.GetPort("26657/tcp"))
+	grpcAddr = fmt.Sprintf("https://localhost:%s", resource.GetPort("9090/tcp"))
+
+	return rpcAddr, grpcAddr, nil
+}
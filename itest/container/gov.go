@@ -0,0 +1,146 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+// packageDir is this package's directory on disk, used to resolve
+// Dockerfiles supplied by name (e.g. "Dockerfile.post-upgrade") regardless
+// of the calling test binary's working directory.
+func packageDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}
+
+// GovProposal is the subset of `babylond query gov proposal` output the
+// test manager needs to decide whether a proposal has passed.
+type GovProposal struct {
+	ProposalID string `json:"id"`
+	Status     string `json:"status"`
+}
+
+// SubmitGovProposal runs `babylond tx gov submit-proposal <proposalType>
+// <args...>` from node's key and returns the ID assigned to the resulting
+// proposal.
+func (m *Manager) SubmitGovProposal(t *testing.T, node string, proposalType string, args ...string) (uint64, error) {
+	cmdArgs := append([]string{"tx", "gov", "submit-proposal", proposalType}, args...)
+	cmdArgs = append(cmdArgs, "--from", node, "--deposit", "10000000ubbn",
+		"--gas", "auto", "--gas-adjustment", "1.5", "-y", "-o", "json")
+
+	stdout, _, err := m.execBabylondCmd(t, node, cmdArgs...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to submit %s proposal: %w", proposalType, err)
+	}
+
+	var txResp struct {
+		Logs []struct {
+			Events []struct {
+				Type       string `json:"type"`
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value string `json:"value"`
+				} `json:"attributes"`
+			} `json:"events"`
+		} `json:"logs"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &txResp); err != nil {
+		return 0, fmt.Errorf("failed to parse submit-proposal response: %w", err)
+	}
+
+	for _, l := range txResp.Logs {
+		for _, ev := range l.Events {
+			if ev.Type != "submit_proposal" {
+				continue
+			}
+			for _, attr := range ev.Attributes {
+				if attr.Key != "proposal_id" {
+					continue
+				}
+				id, err := strconv.ParseUint(attr.Value, 10, 64)
+				if err != nil {
+					return 0, fmt.Errorf("failed to parse proposal id %q: %w", attr.Value, err)
+				}
+				return id, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("submit-proposal response did not contain a proposal_id event")
+}
+
+// QueryProposal runs `babylond query gov proposal <id>` and returns its
+// status.
+func (m *Manager) QueryProposal(t *testing.T, proposalID uint64) (*GovProposal, error) {
+	stdout, _, err := m.execBabylondCmd(t, "node0", "query", "gov", "proposal",
+		strconv.FormatUint(proposalID, 10), "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query proposal %d: %w", proposalID, err)
+	}
+
+	var proposal GovProposal
+	if err := json.Unmarshal([]byte(stdout), &proposal); err != nil {
+		return nil, fmt.Errorf("failed to parse proposal %d response: %w", proposalID, err)
+	}
+
+	return &proposal, nil
+}
+
+// VoteOnProposal runs `babylond tx gov vote <args...>` from node's key,
+// e.g. VoteOnProposal(t, "node0", "1", "yes") to vote yes on proposal 1.
+func (m *Manager) VoteOnProposal(t *testing.T, node string, args ...string) (string, string, error) {
+	cmdArgs := append([]string{"tx", "gov", "vote"}, args...)
+	cmdArgs = append(cmdArgs, "--from", node, "--gas", "auto", "--gas-adjustment", "1.5", "-y")
+
+	return m.execBabylondCmd(t, node, cmdArgs...)
+}
+
+// UpgradeBabylondResource stops the running babylond container, builds a
+// fresh image from dockerfile (resolved relative to this package's
+// directory, not the calling test binary's working directory), passing
+// version as its VERSION build-arg, and restarts node0 from that image on
+// top of its existing data dir, so the chain resumes post-upgrade rather
+// than from genesis.
+func (m *Manager) UpgradeBabylondResource(t *testing.T, dockerfile string, version string) error {
+	resource, ok := m.resources["node0"]
+	if !ok {
+		return fmt.Errorf("no resource running for node node0")
+	}
+
+	dataMount := resource.Container.Mounts[0].Source
+
+	if err := m.pool.Purge(resource); err != nil {
+		return fmt.Errorf("failed to stop node0 for upgrade: %w", err)
+	}
+
+	if !filepath.IsAbs(dockerfile) {
+		dockerfile = filepath.Join(packageDir(), dockerfile)
+	}
+
+	postUpgradeTag := fmt.Sprintf("%s-%s", babylondRepository, version)
+	newResource, err := m.pool.BuildAndRunWithBuildOptions(
+		&dockertest.BuildOptions{
+			Dockerfile: dockerfile,
+			BuildArgs: []docker.BuildArg{
+				{Name: "VERSION", Value: version},
+			},
+		},
+		postUpgradeTag,
+		[]string{fmt.Sprintf("%s:/babylond", dataMount)},
+		func(hc *docker.HostConfig) {
+			hc.AutoRemove = true
+			hc.RestartPolicy = docker.RestartPolicy{Name: "no"}
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start post-upgrade babylond image: %w", err)
+	}
+
+	m.resources["node0"] = newResource
+
+	return nil
+}
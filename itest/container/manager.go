@@ -0,0 +1,166 @@
+// Package container drives the dockerized babylond node (and, from
+// RegisterConsumerChain onwards, additional consumer-chain nodes) that the
+// itest/babylon e2e suite runs against.
+package container
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+const (
+	babylondRepository = "babylonlabs-io/babylond"
+	babylondTag        = "latest"
+
+	// defaultGovVotingPeriod is used whenever a caller doesn't ask for a
+	// shorter one via NewManager's WithGovVotingPeriod option. It is long
+	// enough that no regular (non-upgrade) test accidentally races a
+	// proposal passing.
+	defaultGovVotingPeriod = 30 * time.Second
+)
+
+// Manager owns the dockertest pool and the resources (babylond and, later,
+// consumer-chain nodes) started against it for a single e2e test run.
+type Manager struct {
+	pool            *dockertest.Pool
+	resources       map[string]*dockertest.Resource
+	govVotingPeriod time.Duration
+}
+
+// ManagerOption configures optional Manager behavior.
+type ManagerOption func(*Manager)
+
+// WithGovVotingPeriod overrides the gov voting_period written into the
+// genesis RunBabylondResource generates, letting tests that submit and pass
+// a proposal (e.g. a software upgrade) do so without waiting out the
+// default voting period.
+func WithGovVotingPeriod(d time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.govVotingPeriod = d
+	}
+}
+
+// NewManager connects to the local docker daemon and prepares an empty
+// resource set. Resources are torn down by ClearResources, which t.Cleanup
+// should normally be wired to call.
+func NewManager(t *testing.T, opts ...ManagerOption) (*Manager, error) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to docker: %w", err)
+	}
+
+	m := &Manager{
+		pool:            pool,
+		resources:       make(map[string]*dockertest.Resource),
+		govVotingPeriod: defaultGovVotingPeriod,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m, nil
+}
+
+// RunBabylondResource starts a single-node babylond devnet in docker,
+// seeded with the given covenant committee and the Manager's configured
+// gov voting_period, and waits for it to accept RPC connections.
+func (m *Manager) RunBabylondResource(t *testing.T, babylonDir string, covenantQuorum int, covenantPubKeys []*btcec.PublicKey) (*dockertest.Resource, error) {
+	resource, err := m.pool.RunWithOptions(&dockertest.RunOptions{
+		Name:       "node0",
+		Repository: babylondRepository,
+		Tag:        babylondTag,
+		Mounts:     []string{fmt.Sprintf("%s:/babylond", babylonDir)},
+		Cmd: []string{
+			"sh", "-c",
+			fmt.Sprintf(
+				"babylond testnet init-files --v 1 --output-dir /babylond "+
+					"--covenant-quorum %d --covenant-pks %s --gov-voting-period %s "+
+					"&& babylond start --home /babylond/node0/babylond",
+				covenantQuorum, covenantPksFlag(covenantPubKeys), m.govVotingPeriod,
+			),
+		},
+	}, func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+		hc.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start babylond resource: %w", err)
+	}
+
+	m.resources["node0"] = resource
+
+	return resource, nil
+}
+
+// covenantPksFlag hex-encodes covenantPubKeys (compressed) into the
+// comma-separated form `babylond testnet init-files --covenant-pks` expects.
+func covenantPksFlag(covenantPubKeys []*btcec.PublicKey) string {
+	pks := make([]string, len(covenantPubKeys))
+	for i, pk := range covenantPubKeys {
+		pks[i] = hex.EncodeToString(pk.SerializeCompressed())
+	}
+
+	return strings.Join(pks, ",")
+}
+
+// BabylondTxBankSend runs `babylond tx bank send` from fromKey to toAddr and
+// returns the CLI's stdout/stderr.
+func (m *Manager) BabylondTxBankSend(t *testing.T, toAddr string, amount string, fromKey string) (string, string, error) {
+	return m.execBabylondCmd(t, "node0", "tx", "bank", "send", fromKey, toAddr, amount,
+		"--gas", "auto", "--gas-adjustment", "1.5", "-y", "-o", "json")
+}
+
+// execBabylondCmd execs a babylond CLI command inside the named node's
+// container and returns its stdout/stderr, trimmed of surrounding docker
+// exec framing.
+func (m *Manager) execBabylondCmd(t *testing.T, node string, args ...string) (string, string, error) {
+	resource, ok := m.resources[node]
+	if !ok {
+		return "", "", fmt.Errorf("no resource running for node %s", node)
+	}
+
+	cmd := append([]string{"babylond"}, args...)
+	exec, err := m.pool.Client.CreateExec(docker.CreateExecOptions{
+		Container:    resource.Container.ID,
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create exec for %v in %s: %w", cmd, node, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := m.pool.Client.StartExec(exec.ID, docker.StartExecOptions{OutputStream: &stdout, ErrorStream: &stderr}); err != nil {
+		return stdout.String(), stderr.String(), fmt.Errorf("failed to run %v in %s: %w", cmd, node, err)
+	}
+
+	inspect, err := m.pool.Client.InspectExec(exec.ID)
+	if err != nil {
+		return stdout.String(), stderr.String(), fmt.Errorf("failed to inspect exec %v in %s: %w", cmd, node, err)
+	}
+	if inspect.ExitCode != 0 {
+		return stdout.String(), stderr.String(), fmt.Errorf("babylond %v exited %d: %s", args, inspect.ExitCode, stderr.String())
+	}
+
+	return stdout.String(), stderr.String(), nil
+}
+
+// ClearResources purges every docker resource the Manager started.
+func (m *Manager) ClearResources() error {
+	for name, resource := range m.resources {
+		if err := m.pool.Purge(resource); err != nil {
+			return fmt.Errorf("failed to purge resource %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
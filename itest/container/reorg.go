@@ -0,0 +1,79 @@
+package container
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+// RollbackBabylondResource stops node, rolls its chain state back depth
+// blocks via `babylond rollback --hard` run one-shot against its data dir,
+// and restarts the node, producing a reorg of the chain any FP watching it
+// has in flight.
+func (m *Manager) RollbackBabylondResource(t *testing.T, node string, depth int) error {
+	resource, ok := m.resources[node]
+	if !ok {
+		return fmt.Errorf("no resource running for node %s", node)
+	}
+
+	dataDir := resource.Container.Mounts[0].Source
+
+	if err := m.pool.Purge(resource); err != nil {
+		return fmt.Errorf("failed to stop %s for rollback: %w", node, err)
+	}
+
+	for i := 0; i < depth; i++ {
+		if err := m.runBabylondOneShot(dataDir, "rollback", "--hard", "--home", "/babylond/node0/babylond"); err != nil {
+			return fmt.Errorf("failed to roll back %s (step %d/%d): %w", node, i+1, depth, err)
+		}
+	}
+
+	restarted, err := m.pool.RunWithOptions(&dockertest.RunOptions{
+		Name:       node,
+		Repository: babylondRepository,
+		Tag:        babylondTag,
+		Mounts:     []string{fmt.Sprintf("%s:/babylond", dataDir)},
+		Cmd:        []string{"babylond", "start", "--home", "/babylond/node0/babylond"},
+	}, func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+		hc.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restart %s after rollback: %w", node, err)
+	}
+
+	m.resources[node] = restarted
+
+	return nil
+}
+
+// runBabylondOneShot runs a babylond CLI command against dataDir in its
+// own short-lived container (rather than exec-ing into node0's, which by
+// the time this is called has already been stopped) and blocks until it
+// exits, returning an error if it exited non-zero.
+func (m *Manager) runBabylondOneShot(dataDir string, args ...string) error {
+	cmd := append([]string{"babylond"}, args...)
+
+	resource, err := m.pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: babylondRepository,
+		Tag:        babylondTag,
+		Mounts:     []string{fmt.Sprintf("%s:/babylond", dataDir)},
+		Cmd:        cmd,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run %v: %w", cmd, err)
+	}
+	defer m.pool.Purge(resource)
+
+	exitCode, err := m.pool.Client.WaitContainer(resource.Container.ID)
+	if err != nil {
+		return fmt.Errorf("failed waiting for %v to finish: %w", cmd, err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("%v exited %d", cmd, exitCode)
+	}
+
+	return nil
+}